@@ -0,0 +1,36 @@
+package recurrent
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithBackoff configures a scheduler created by NewRetryScheduler to grow the
+// interval between invocations geometrically after each failing invocation of
+// the target function, and to reset it back to min after a successful one.
+// The interval starts at min, is multiplied by factor after each failure (and
+// capped at max), and is perturbed by a uniform jitter in the range
+// [-jitter*interval, +jitter*interval]. On a scheduler created with
+// NewScheduler or NewSchedulerCtx the interval never grows, since their
+// target functions report no failures, but this option still sets the
+// interval to min and Signal still resets it to min.
+func WithBackoff(min, max time.Duration, factor float64, jitter float64) ConfigFunc {
+	return func(s *scheduler) {
+		s.backoffEnabled = true
+		s.backoffMin = min
+		s.backoffMax = max
+		s.backoffFactor = factor
+		s.backoffJitter = jitter
+		s.interval = min
+		s.currentBackoff = min
+	}
+}
+
+func jitter(interval time.Duration, factor float64) time.Duration {
+	if factor == 0 {
+		return interval
+	}
+
+	delta := (rand.Float64()*2 - 1) * factor * float64(interval)
+	return interval + time.Duration(delta)
+}
@@ -0,0 +1,163 @@
+package recurrent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sniperkit/recurrent/recurrenttest"
+)
+
+func TestBackoffGrowsOnFailureCapsAtMaxAndResetsOnSuccess(t *testing.T) {
+	var (
+		clock = recurrenttest.NewClock()
+		sync  = make(chan struct{})
+		fail  = true
+	)
+
+	target := func() error {
+		defer func() { sync <- struct{}{} }()
+
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}
+
+	s := NewRetryScheduler(
+		target,
+		WithBackoff(10*time.Millisecond, 100*time.Millisecond, 2, 0),
+		WithClock(clock),
+	).(*scheduler)
+
+	s.Start()
+	defer s.Stop()
+
+	// nextBackoff advances the clock by the current interval, waits for the
+	// resulting invocation, and then waits for the scheduler to have armed
+	// two timers: the one it arms right after the fire, and the one it arms
+	// once onFailure/onSuccess have updated s.currentBackoff and the Reset
+	// has been processed. Both earlier timers fire during the Add, so the
+	// second timer can only appear after the Reset, and reading
+	// s.currentBackoff below is never racing with the scheduler goroutine.
+	nextBackoff := func(wait time.Duration) time.Duration {
+		clock.BlockUntil(1)
+		clock.Add(wait)
+		<-sync
+		clock.BlockUntil(2)
+		return s.currentBackoff
+	}
+
+	if got, want := nextBackoff(10*time.Millisecond), 20*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to grow to %s after first failure, got %s", want, got)
+	}
+
+	if got, want := nextBackoff(20*time.Millisecond), 40*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to grow to %s after second failure, got %s", want, got)
+	}
+
+	if got, want := nextBackoff(40*time.Millisecond), 80*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to grow to %s after third failure, got %s", want, got)
+	}
+
+	if got, want := nextBackoff(80*time.Millisecond), 100*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to cap at max %s, got %s", want, got)
+	}
+
+	fail = false
+
+	if got, want := nextBackoff(100*time.Millisecond), 10*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to reset to min %s after a success, got %s", want, got)
+	}
+}
+
+func TestBackoffSignalResetsInterval(t *testing.T) {
+	var (
+		clock = recurrenttest.NewClock()
+		sync  = make(chan struct{})
+	)
+
+	target := func() error {
+		defer func() { sync <- struct{}{} }()
+		return errors.New("boom")
+	}
+
+	s := NewRetryScheduler(
+		target,
+		WithBackoff(10*time.Millisecond, time.Second, 2, 0),
+		WithClock(clock),
+	).(*scheduler)
+
+	s.Start()
+	defer s.Stop()
+
+	clock.BlockUntil(1)
+	clock.Add(10 * time.Millisecond)
+	<-sync
+	// One timer is armed right after the fire, the second once the Reset
+	// from onFailure has been processed.
+	clock.BlockUntil(2)
+
+	if got, want := s.currentBackoff, 20*time.Millisecond; got != want {
+		t.Fatalf("expected backoff to grow to %s after a failure, got %s", want, got)
+	}
+
+	// Signal resets currentBackoff to min synchronously, before the
+	// invocation it triggers even runs.
+	s.Signal()
+
+	if got, want := s.currentBackoff, 10*time.Millisecond; got != want {
+		t.Fatalf("expected Signal to reset backoff to min %s, got %s", want, got)
+	}
+
+	<-sync
+	clock.BlockUntil(1)
+
+	if got, want := s.currentBackoff, 20*time.Millisecond; got != want {
+		t.Fatalf("expected the signaled invocation's failure to grow backoff from min to %s, got %s", want, got)
+	}
+}
+
+func TestBackoffSignalDuringFailureDoesNotRace(t *testing.T) {
+	target := func() error {
+		time.Sleep(time.Millisecond)
+		return errors.New("boom")
+	}
+
+	s := NewRetryScheduler(
+		target,
+		WithInterval(time.Millisecond),
+		WithBackoff(time.Millisecond, 10*time.Millisecond, 2, 0),
+	).(*scheduler)
+
+	s.Start()
+
+	// Signal from this goroutine while the scheduler's own goroutine is
+	// concurrently running target and onFailure, which write
+	// s.currentBackoff under s.backoffMutex. Run with -race to confirm
+	// there is no unsynchronized access left on either side.
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		s.Signal()
+	}
+
+	s.Stop()
+	s.Wait()
+}
+
+func TestJitterWithinRange(t *testing.T) {
+	if got := jitter(100*time.Millisecond, 0); got != 100*time.Millisecond {
+		t.Fatalf("expected zero jitter factor to leave the interval unchanged, got %s", got)
+	}
+
+	const factor = 0.25
+	min := 100*time.Millisecond - time.Duration(factor*float64(100*time.Millisecond))
+	max := 100*time.Millisecond + time.Duration(factor*float64(100*time.Millisecond))
+
+	for i := 0; i < 1000; i++ {
+		got := jitter(100*time.Millisecond, factor)
+		if got < min || got > max {
+			t.Fatalf("expected jittered interval within [%s, %s], got %s", min, max, got)
+		}
+	}
+}
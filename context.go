@@ -0,0 +1,211 @@
+package recurrent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// OverlapPolicy determines what a scheduler created with NewSchedulerCtx
+	// does when its target function is still running at the next scheduled
+	// fire.
+	OverlapPolicy int
+
+	ctxRunner struct {
+		target     func(context.Context) error
+		timeout    time.Duration
+		policy     OverlapPolicy
+		sem        chan struct{}
+		mutex      sync.Mutex
+		running    bool
+		cancel     context.CancelFunc
+		done       chan struct{}
+		queued     bool
+		signalFunc func()
+	}
+)
+
+const (
+	// Skip drops a fire while a previous invocation of the target function
+	// is still running. This is the default.
+	Skip OverlapPolicy = iota
+
+	// Queue coalesces fires that arrive while a previous invocation is
+	// still running into a single extra invocation, started as soon as the
+	// current one completes.
+	Queue
+
+	// Cancel cancels the context of a running invocation and starts a new
+	// invocation as soon as the canceled one has returned.
+	Cancel
+
+	// Concurrent launches each fire in its own goroutine, up to the limit
+	// given to WithOverlapPolicy.
+	Concurrent
+)
+
+// NewSchedulerCtx creates a new scheduler whose target function is invoked
+// with a context.Context, bounded by WithInvocationTimeout if supplied. The
+// behavior when a fire arrives while a previous invocation is still running
+// is controlled by WithOverlapPolicy (default Skip).
+func NewSchedulerCtx(target func(context.Context) error, configs ...ConfigFunc) Scheduler {
+	scheduler := newScheduler()
+
+	runner := &ctxRunner{
+		target:     target,
+		policy:     Skip,
+		sem:        make(chan struct{}, 1),
+		signalFunc: scheduler.Signal,
+	}
+	scheduler.ctxRunner = runner
+	scheduler.target = runner.fire
+
+	for _, config := range configs {
+		config(scheduler)
+	}
+
+	return scheduler
+}
+
+// WithInvocationTimeout bounds each invocation of a context-aware target
+// function (see NewSchedulerCtx) to the given duration. The invocation's
+// context is canceled after the timeout; a target function that ignores
+// cancellation still runs until it returns, and until then it counts as
+// running for every overlap policy (under Cancel the next invocation waits
+// for it, under Concurrent it keeps its slot). This option has no effect on
+// a scheduler created with NewScheduler or NewRetryScheduler.
+func WithInvocationTimeout(d time.Duration) ConfigFunc {
+	return func(s *scheduler) {
+		if s.ctxRunner != nil {
+			s.ctxRunner.timeout = d
+		}
+	}
+}
+
+// WithOverlapPolicy controls what a scheduler created with NewSchedulerCtx
+// does when the target function is still running at the next fire.
+// maxConcurrent bounds the number of simultaneous invocations under the
+// Concurrent policy (it is ignored by the other policies, and defaults to 1
+// if not positive). This option has no effect on a scheduler created with
+// NewScheduler or NewRetryScheduler.
+func WithOverlapPolicy(policy OverlapPolicy, maxConcurrent int) ConfigFunc {
+	return func(s *scheduler) {
+		if s.ctxRunner == nil {
+			return
+		}
+
+		if maxConcurrent <= 0 {
+			maxConcurrent = 1
+		}
+
+		s.ctxRunner.policy = policy
+		s.ctxRunner.sem = make(chan struct{}, maxConcurrent)
+	}
+}
+
+func (r *ctxRunner) fire() {
+	switch r.policy {
+	case Concurrent:
+		select {
+		case r.sem <- struct{}{}:
+			r.launch(func() { <-r.sem })
+		default:
+		}
+
+	case Cancel:
+		r.mutex.Lock()
+		cancel, done := r.cancel, r.done
+		r.mutex.Unlock()
+
+		if cancel != nil {
+			// Cancel the running invocation and wait for it to actually
+			// return before starting the next one. launch registers the
+			// next invocation's r.cancel/r.done synchronously before its
+			// goroutine is spawned (see below), so a Signal arriving right
+			// after this call can never observe a stale nil r.cancel and
+			// slip past this wait into a second concurrent invocation.
+			cancel()
+			<-done
+		}
+
+		r.launch(nil)
+
+	case Queue:
+		r.mutex.Lock()
+		if r.running {
+			r.queued = true
+			r.mutex.Unlock()
+			return
+		}
+		r.mutex.Unlock()
+		r.launch(nil)
+
+	default: // Skip
+		r.mutex.Lock()
+		if r.running {
+			r.mutex.Unlock()
+			return
+		}
+		r.mutex.Unlock()
+		r.launch(nil)
+	}
+}
+
+// launch registers a new invocation's running state under r.mutex and then
+// starts it in a goroutine. The registration happens synchronously in the
+// caller's goroutine, before r.run is spawned, so that a concurrent fire()
+// call always sees an up-to-date r.running/r.cancel/r.done rather than
+// racing with run's own startup. after, if non-nil, runs once the
+// invocation completes (used by the Concurrent policy to release its
+// semaphore slot).
+//
+// r.running/r.cancel/r.done track a single in-flight invocation and are
+// only meaningful to the Skip, Queue, and Cancel policies, which never let
+// more than one invocation run at a time; under Concurrent, multiple
+// invocations are in flight simultaneously, so launch and run leave these
+// fields untouched rather than having one invocation's bookkeeping
+// overwrite (or zero out) another's.
+func (r *ctxRunner) launch(after func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if r.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+	}
+	done := make(chan struct{})
+
+	r.mutex.Lock()
+	if r.policy != Concurrent {
+		r.running = true
+		r.cancel = cancel
+		r.done = done
+	}
+	r.mutex.Unlock()
+
+	go r.run(ctx, cancel, done, after)
+}
+
+func (r *ctxRunner) run(ctx context.Context, cancel context.CancelFunc, done chan struct{}, after func()) {
+	defer cancel()
+
+	r.target(ctx)
+
+	r.mutex.Lock()
+	if r.policy != Concurrent {
+		r.running = false
+		r.cancel = nil
+		r.done = nil
+	}
+	requeue := r.queued
+	r.queued = false
+	r.mutex.Unlock()
+
+	close(done)
+
+	if after != nil {
+		after()
+	}
+
+	if requeue {
+		r.signalFunc()
+	}
+}
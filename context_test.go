@@ -0,0 +1,228 @@
+package recurrent
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCancelOverlapPolicyBoundsConcurrency(t *testing.T) {
+	var (
+		running       int32
+		maxConcurrent int32
+	)
+
+	target := func(ctx context.Context) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxConcurrent)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxConcurrent, observed, current) {
+				break
+			}
+		}
+
+		// Ignores ctx.Done() for a while, like a target that is slow to
+		// notice cancellation, so Signal can arrive well before this
+		// invocation actually exits.
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	sched := NewSchedulerCtx(
+		target,
+		WithInterval(time.Hour),
+		WithOverlapPolicy(Cancel, 1),
+	)
+
+	sched.Start()
+
+	for i := 0; i < 15; i++ {
+		sched.Signal()
+		time.Sleep(time.Millisecond)
+	}
+
+	sched.Stop()
+
+	// Wait for the scheduler's own goroutine to return, which guarantees no
+	// further invocations can be launched, then wait on whichever invocation
+	// was running (if any) via its done channel, read under the same mutex
+	// ctxRunner itself uses. This synchronizes on actual completion instead
+	// of assuming a fixed sleep was long enough.
+	s := sched.(*scheduler)
+	<-s.done
+
+	s.ctxRunner.mutex.Lock()
+	last := s.ctxRunner.done
+	s.ctxRunner.mutex.Unlock()
+	if last != nil {
+		<-last
+	}
+
+	if observed := atomic.LoadInt32(&maxConcurrent); observed > 1 {
+		t.Fatalf("expected at most 1 concurrently running invocation under the Cancel policy, observed %d", observed)
+	}
+}
+
+func TestSkipOverlapPolicyDropsFireWhileRunning(t *testing.T) {
+	var attempts int32
+
+	target := func(ctx context.Context) error {
+		atomic.AddInt32(&attempts, 1)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	// Skip is the default, so no WithOverlapPolicy is given here.
+	sched := NewSchedulerCtx(target, WithInterval(time.Hour))
+
+	sched.Start()
+
+	for i := 0; i < 15; i++ {
+		sched.Signal()
+		time.Sleep(time.Millisecond)
+	}
+
+	sched.Stop()
+
+	s := sched.(*scheduler)
+	<-s.done
+
+	// 15 signals arrive roughly 1ms apart while each invocation takes
+	// 20ms, so Skip must drop most of them and let only a handful of
+	// non-overlapping invocations through.
+	if got := atomic.LoadInt32(&attempts); got == 0 || got >= 15 {
+		t.Fatalf("expected Skip to drop signals that arrive while an invocation is running, got %d attempts out of 15 signals", got)
+	}
+}
+
+func TestQueueOverlapPolicyCoalescesFiresWhileRunning(t *testing.T) {
+	var (
+		calls   = make(chan struct{}, 10)
+		release = make(chan struct{})
+		first   = true
+	)
+
+	target := func(ctx context.Context) error {
+		calls <- struct{}{}
+
+		if first {
+			first = false
+			<-release
+		}
+
+		return nil
+	}
+
+	sched := NewSchedulerCtx(
+		target,
+		WithInterval(time.Hour),
+		WithOverlapPolicy(Queue, 1),
+	)
+
+	sched.Start()
+	defer sched.Stop()
+
+	sched.Signal()
+	<-calls // the first invocation has started and is now blocked on release
+
+	for i := 0; i < 5; i++ {
+		sched.Signal()
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("expected the queued fires to coalesce into one invocation once the first completed")
+	}
+
+	select {
+	case <-calls:
+		t.Fatal("expected the 5 queued signals to coalesce into exactly one invocation, got a second")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestConcurrentOverlapPolicyBoundsConcurrency(t *testing.T) {
+	var (
+		running       int32
+		maxConcurrent int32
+	)
+
+	target := func(ctx context.Context) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxConcurrent)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxConcurrent, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}
+
+	sched := NewSchedulerCtx(
+		target,
+		WithInterval(time.Hour),
+		WithOverlapPolicy(Concurrent, 2),
+	)
+
+	sched.Start()
+
+	for i := 0; i < 15; i++ {
+		sched.Signal()
+		time.Sleep(time.Millisecond)
+	}
+
+	sched.Stop()
+
+	s := sched.(*scheduler)
+	<-s.done
+
+	if observed := atomic.LoadInt32(&maxConcurrent); observed == 0 {
+		t.Fatal("expected at least one invocation to run")
+	} else if observed > 2 {
+		t.Fatalf("expected at most 2 concurrently running invocations under Concurrent(2), observed %d", observed)
+	}
+}
+
+func TestInvocationTimeoutCancelsContext(t *testing.T) {
+	var (
+		start = time.Now()
+		done  = make(chan time.Duration, 1)
+	)
+
+	target := func(ctx context.Context) error {
+		<-ctx.Done()
+		done <- time.Since(start)
+		return ctx.Err()
+	}
+
+	sched := NewSchedulerCtx(
+		target,
+		WithInterval(time.Hour),
+		WithInvocationTimeout(20*time.Millisecond),
+	)
+
+	sched.Start()
+	defer sched.Stop()
+
+	sched.Signal()
+
+	select {
+	case elapsed := <-done:
+		if elapsed < 20*time.Millisecond {
+			t.Fatalf("expected the invocation's context to be canceled no sooner than the configured timeout, got %s", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected WithInvocationTimeout to cancel the invocation's context")
+	}
+}
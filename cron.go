@@ -0,0 +1,190 @@
+package recurrent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a ScheduleSource driven by a standard 5-field cron
+// expression (minute hour day-of-month month day-of-week), the same format
+// used by crontab(5) and robfig/cron. Each field accepts "*", a single
+// value, a comma-separated list, a range ("1-5"), and a step ("*/5",
+// "1-10/2"). As in crontab(5), if both day-of-month and day-of-week are
+// restricted (neither is "*"), a time matches when either field matches,
+// not only when both do.
+type CronSchedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+
+	domRestricted bool
+	dowRestricted bool
+}
+
+// NewCronSchedule parses a standard 5-field cron expression into a
+// ScheduleSource.
+func NewCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("recurrent: expected 5 fields in cron expression, got %d", len(fields))
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, err
+	}
+
+	daysOfMonth, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, err
+	}
+
+	daysOfWeek, err := parseCronField(fields[4], 0, 6)
+	if err != nil {
+		return nil, err
+	}
+
+	// A restricted day-of-week still matches on its own (see CronSchedule),
+	// so only an expression that relies on day-of-month alone is rejected.
+	if fields[2] != "*" && fields[4] == "*" && !domAchievable(daysOfMonth, months) {
+		return nil, fmt.Errorf("recurrent: day-of-month field %q is not achievable in any month matched by %q", fields[2], fields[3])
+	}
+
+	return &CronSchedule{
+		minutes:     minutes,
+		hours:       hours,
+		daysOfMonth: daysOfMonth,
+		months:      months,
+		daysOfWeek:  daysOfWeek,
+
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}, nil
+}
+
+// Next implements ScheduleSource. It returns the first whole minute after
+// now whose fields satisfy the cron expression.
+func (s *CronSchedule) Next(now time.Time) time.Time {
+	next := now.Truncate(time.Minute).Add(time.Minute)
+
+	// NewCronSchedule rejects a day-of-month field that is unachievable in
+	// every selected month, so a valid *CronSchedule is guaranteed to repeat
+	// at least once within eight years (the longest gap between two leap
+	// days, across a century year such as 2100 that is not a leap year),
+	// which bounds this search.
+	for i := 0; i < 8*366*24*60; i++ {
+		if s.matches(next) {
+			return next
+		}
+
+		next = next.Add(time.Minute)
+	}
+
+	return next
+}
+
+func (s *CronSchedule) matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[int(t.Month())] {
+		return false
+	}
+
+	domMatch := s.daysOfMonth[t.Day()]
+	dowMatch := s.daysOfWeek[int(t.Weekday())]
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+// domAchievable reports whether at least one value in daysOfMonth falls
+// within the length of at least one month in months. 2024 is used to size
+// each month so that February 29 counts as achievable even though it
+// doesn't occur every year; a day that's out of range in every year (e.g.
+// day 31 in a month selection restricted to February) is still rejected.
+func domAchievable(daysOfMonth, months map[int]bool) bool {
+	for month := range months {
+		lastDay := time.Date(2024, time.Month(month+1), 0, 0, 0, 0, 0, time.UTC).Day()
+
+		for day := range daysOfMonth {
+			if day <= lastDay {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+
+	for _, part := range strings.Split(field, ",") {
+		rangeMin, rangeMax, step := min, max, 1
+
+		rangePart := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			rangePart = part[:idx]
+
+			parsedStep, err := strconv.Atoi(part[idx+1:])
+			if err != nil || parsedStep <= 0 {
+				return nil, fmt.Errorf("recurrent: invalid step in cron field %q", field)
+			}
+
+			step = parsedStep
+		}
+
+		switch {
+		case rangePart == "*":
+			// rangeMin/rangeMax already cover the full field range
+
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+
+			lo, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, fmt.Errorf("recurrent: invalid range in cron field %q", field)
+			}
+
+			hi, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, fmt.Errorf("recurrent: invalid range in cron field %q", field)
+			}
+
+			rangeMin, rangeMax = lo, hi
+
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("recurrent: invalid value in cron field %q", field)
+			}
+
+			rangeMin, rangeMax = value, value
+		}
+
+		if rangeMin < min || rangeMax > max || rangeMin > rangeMax {
+			return nil, fmt.Errorf("recurrent: value out of range in cron field %q", field)
+		}
+
+		for v := rangeMin; v <= rangeMax; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,54 @@
+package recurrent
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleDayOfMonthOrDayOfWeek(t *testing.T) {
+	// Fires at midnight on the 10th of the month OR every Monday. 2026-07-22
+	// is a Wednesday; the 10th has already passed for July and the next one
+	// (August 10) is over two weeks away, but the next Monday (July 27) is
+	// only a few days out. A scheduler that (incorrectly) ANDs day-of-month
+	// and day-of-week together would skip every Monday that isn't also the
+	// 10th and jump all the way to August 10.
+	schedule, err := NewCronSchedule("0 0 10 * 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	now := time.Date(2026, 7, 22, 12, 0, 0, 0, time.UTC)
+	next := schedule.Next(now)
+
+	if next.Weekday() != time.Monday {
+		t.Fatalf("expected next fire on a Monday, got %s (%s)", next.Weekday(), next)
+	}
+
+	if d := next.Sub(now); d > 7*24*time.Hour {
+		t.Fatalf("expected next fire within a week, got %s away (%s)", d, next)
+	}
+}
+
+func TestNewCronScheduleRejectsUnsatisfiableDayOfMonth(t *testing.T) {
+	if _, err := NewCronSchedule("0 0 31 2 *"); err == nil {
+		t.Fatal("expected an error for a day-of-month that no selected month can reach")
+	}
+
+	if _, err := NewCronSchedule("0 0 30,31 2 *"); err == nil {
+		t.Fatal("expected an error when every day-of-month candidate exceeds February's length")
+	}
+}
+
+func TestNewCronScheduleAllowsLeapDayInFebruary(t *testing.T) {
+	schedule, err := NewCronSchedule("0 0 29 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := schedule.Next(now)
+
+	if next.Month() != time.February || next.Day() != 29 {
+		t.Fatalf("expected the next Feb 29 (a leap year), got %s", next)
+	}
+}
@@ -0,0 +1,105 @@
+package recurrent
+
+import (
+	"sync"
+
+	"github.com/efritz/glock"
+)
+
+// SchedulerGroup coordinates a set of named schedulers that share a single
+// clock, so that tests can advance simulated time for every member of the
+// group atomically (see WithClock and Clock).
+type SchedulerGroup struct {
+	clock glock.Clock
+
+	mutex      sync.Mutex
+	schedulers map[string]Scheduler
+
+	wg sync.WaitGroup
+}
+
+// NewSchedulerGroup creates an empty SchedulerGroup backed by a real clock.
+func NewSchedulerGroup() *SchedulerGroup {
+	return NewSchedulerGroupWithClock(glock.NewRealClock())
+}
+
+// NewSchedulerGroupWithClock creates an empty SchedulerGroup backed by the
+// given clock, such as a recurrenttest.Clock in tests.
+func NewSchedulerGroupWithClock(clock glock.Clock) *SchedulerGroup {
+	return &SchedulerGroup{
+		clock:      clock,
+		schedulers: map[string]Scheduler{},
+	}
+}
+
+// Clock returns the clock shared by this group. Construct member schedulers
+// with recurrent.WithClock(group.Clock()) so that they all observe the same
+// notion of time before adding them to the group.
+func (g *SchedulerGroup) Clock() glock.Clock {
+	return g.clock
+}
+
+// Add registers a scheduler under the given name. If a scheduler is already
+// registered under that name, it is replaced (the previous scheduler is not
+// stopped).
+func (g *SchedulerGroup) Add(name string, s Scheduler) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.schedulers[name] = s
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		s.Wait()
+	}()
+}
+
+// Remove unregisters the scheduler with the given name. It does not stop
+// the scheduler.
+func (g *SchedulerGroup) Remove(name string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	delete(g.schedulers, name)
+}
+
+// StopAll calls Stop on every scheduler currently registered in the group.
+func (g *SchedulerGroup) StopAll() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, s := range g.schedulers {
+		s.Stop()
+	}
+}
+
+// SignalAll calls Signal on every scheduler currently registered in the
+// group.
+func (g *SchedulerGroup) SignalAll() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, s := range g.schedulers {
+		s.Signal()
+	}
+}
+
+// SignalOne calls Signal on the scheduler registered under the given name.
+// It is a no-op if no scheduler is registered under that name.
+func (g *SchedulerGroup) SignalOne(name string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if s, ok := g.schedulers[name]; ok {
+		s.Signal()
+	}
+}
+
+// Wait blocks until every scheduler added to this group (including those
+// later removed via Remove) has returned from its Start goroutine, which
+// requires StopAll (or an individual Stop) to have been called.
+func (g *SchedulerGroup) Wait() {
+	g.wg.Wait()
+}
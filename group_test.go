@@ -0,0 +1,115 @@
+package recurrent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sniperkit/recurrent/recurrenttest"
+)
+
+func TestSchedulerGroupWaitBlocksUntilMembersExit(t *testing.T) {
+	group := NewSchedulerGroup()
+
+	blockA := make(chan struct{})
+	blockB := make(chan struct{})
+
+	a := NewScheduler(func() { <-blockA }, WithInterval(time.Hour))
+	b := NewScheduler(func() { <-blockB }, WithInterval(time.Hour))
+
+	group.Add("a", a)
+	group.Add("b", b)
+
+	a.Start()
+	b.Start()
+
+	waitDone := make(chan struct{})
+	go func() {
+		defer close(waitDone)
+		group.Wait()
+	}()
+
+	select {
+	case <-waitDone:
+		t.Fatal("Wait returned before StopAll was called")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	group.StopAll()
+
+	select {
+	case <-waitDone:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after StopAll")
+	}
+
+	close(blockA)
+	close(blockB)
+}
+
+func TestSchedulerGroupSharesClockAcrossMembers(t *testing.T) {
+	clock := recurrenttest.NewClock()
+	group := NewSchedulerGroupWithClock(clock)
+
+	callsA := make(chan struct{}, 1)
+	callsB := make(chan struct{}, 1)
+
+	a := NewScheduler(func() { callsA <- struct{}{} }, WithInterval(time.Second), WithClock(group.Clock()))
+	b := NewScheduler(func() { callsB <- struct{}{} }, WithInterval(time.Second), WithClock(group.Clock()))
+
+	group.Add("a", a)
+	group.Add("b", b)
+
+	a.Start()
+	b.Start()
+	defer group.StopAll()
+
+	clock.BlockUntil(2)
+	clock.Add(time.Second)
+
+	for name, ch := range map[string]chan struct{}{"a": callsA, "b": callsB} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("scheduler %q was not driven by the group's shared clock", name)
+		}
+	}
+}
+
+func TestSchedulerGroupSignalOneAndSignalAll(t *testing.T) {
+	group := NewSchedulerGroup()
+
+	callsA := make(chan struct{}, 1)
+	callsB := make(chan struct{}, 1)
+
+	a := NewScheduler(func() { callsA <- struct{}{} }, WithInterval(time.Hour))
+	b := NewScheduler(func() { callsB <- struct{}{} }, WithInterval(time.Hour))
+
+	group.Add("a", a)
+	group.Add("b", b)
+
+	a.Start()
+	b.Start()
+	defer group.StopAll()
+
+	group.SignalOne("a")
+
+	select {
+	case <-callsA:
+	case <-time.After(time.Second):
+		t.Fatal("SignalOne did not fire the named scheduler")
+	}
+
+	select {
+	case <-callsB:
+		t.Fatal("SignalOne fired a scheduler other than the one named")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	group.SignalAll()
+
+	select {
+	case <-callsB:
+	case <-time.After(time.Second):
+		t.Fatal("SignalAll did not fire every scheduler in the group")
+	}
+}
@@ -0,0 +1,45 @@
+// Package recurrenttest provides a deterministic virtual clock for testing
+// schedulers built with the recurrent package.
+package recurrenttest
+
+import (
+	"time"
+
+	"github.com/efritz/glock"
+)
+
+// Clock is a deterministic virtual clock, inspired by facebookgo/clock, that
+// can be shared with a recurrent.Scheduler via recurrent.WithClock (or with
+// a recurrent.SchedulerGroup). Time only advances when Add or Set is
+// called, firing any pending After channel or Ticker tick whose deadline
+// falls within the advanced window, in timestamp order. This lets a test
+// drive a scheduler through an exact number of intervals and assert on the
+// resulting invocation count without racing against real time.
+type Clock struct {
+	*glock.MockClock
+}
+
+// NewClock creates a new Clock set to the current wall-clock time.
+func NewClock() *Clock {
+	return &Clock{MockClock: glock.NewMockClock()}
+}
+
+// Add advances the clock by the given duration.
+func (c *Clock) Add(d time.Duration) {
+	c.MockClock.Advance(d)
+}
+
+// Set moves the clock to the given time.
+func (c *Clock) Set(t time.Time) {
+	c.MockClock.SetCurrent(t)
+}
+
+// BlockUntil blocks until the clock has at least n outstanding After timers
+// registered, i.e. until a scheduler goroutine has parked on the next call
+// to After. glock's MockClock has no blocking wait for this, so it is
+// implemented by polling BlockedOnAfter.
+func (c *Clock) BlockUntil(n int) {
+	for c.MockClock.BlockedOnAfter() < n {
+		time.Sleep(time.Millisecond)
+	}
+}
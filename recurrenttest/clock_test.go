@@ -0,0 +1,33 @@
+package recurrenttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sniperkit/recurrent"
+)
+
+func TestClockDrivesScheduler(t *testing.T) {
+	var (
+		clock = NewClock()
+		calls = make(chan struct{}, 1)
+	)
+
+	scheduler := recurrent.NewScheduler(
+		func() { calls <- struct{}{} },
+		recurrent.WithInterval(time.Second),
+		recurrent.WithClock(clock),
+	)
+
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	clock.BlockUntil(1)
+	clock.Add(time.Second)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("target was not invoked after advancing the clock")
+	}
+}
@@ -0,0 +1,38 @@
+package recurrent
+
+import "time"
+
+// ScheduleSource determines the time at which a scheduler should next invoke
+// its target function.
+type ScheduleSource interface {
+	// Next returns the time at which the scheduler should next fire, given
+	// the current time as observed by the scheduler's clock.
+	Next(now time.Time) time.Time
+}
+
+// WithSchedule configures the scheduler to fire according to the given
+// ScheduleSource instead of the fixed interval set by WithInterval (or its
+// default). Reset still updates the configured interval, but has no effect
+// on the scheduler's timing once a schedule is set, since there is
+// currently no way to remove it and fall back to the interval again.
+func WithSchedule(schedule ScheduleSource) ConfigFunc {
+	return func(s *scheduler) {
+		s.schedule = schedule
+	}
+}
+
+// FixedSchedule is a ScheduleSource that fires at a constant interval,
+// reproducing the behavior of a scheduler configured with WithInterval.
+type FixedSchedule struct {
+	interval time.Duration
+}
+
+// NewFixedSchedule creates a ScheduleSource that fires every interval.
+func NewFixedSchedule(interval time.Duration) *FixedSchedule {
+	return &FixedSchedule{interval: interval}
+}
+
+// Next implements ScheduleSource.
+func (s *FixedSchedule) Next(now time.Time) time.Time {
+	return now.Add(s.interval)
+}
@@ -0,0 +1,69 @@
+package recurrent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sniperkit/recurrent/recurrenttest"
+)
+
+func TestSchedulerFiresOnFixedSchedule(t *testing.T) {
+	var (
+		clock = recurrenttest.NewClock()
+		calls = make(chan struct{}, 1)
+	)
+
+	scheduler := NewScheduler(
+		func() { calls <- struct{}{} },
+		// Set far longer than the schedule below so the test fails loudly
+		// if nextWait ever falls back to the interval instead of asking
+		// the schedule.
+		WithInterval(time.Hour),
+		WithSchedule(NewFixedSchedule(10*time.Millisecond)),
+		WithClock(clock),
+	)
+
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	clock.BlockUntil(1)
+	clock.Add(10 * time.Millisecond)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not fire on the configured schedule's cadence")
+	}
+}
+
+func TestSchedulerFiresOnCronSchedule(t *testing.T) {
+	var (
+		clock = recurrenttest.NewClock()
+		calls = make(chan struct{}, 1)
+	)
+
+	clock.Set(time.Date(2026, 7, 30, 11, 59, 0, 0, time.UTC))
+
+	schedule, err := NewCronSchedule("0 12 * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	scheduler := NewScheduler(
+		func() { calls <- struct{}{} },
+		WithSchedule(schedule),
+		WithClock(clock),
+	)
+
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	clock.BlockUntil(1)
+	clock.Add(time.Minute)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not fire at the cron schedule's next matching minute")
+	}
+}
@@ -1,6 +1,7 @@
 package recurrent
 
 import (
+	"sync"
 	"time"
 
 	"github.com/efritz/glock"
@@ -22,6 +23,33 @@ type (
 		// always non-blocking, and may be ignored depending on if the scheduler is
 		// throttling signals or not. This method must not be called after Stop.
 		Signal()
+
+		// Reset changes the interval at which the scheduler fires. The timer
+		// currently being waited on is canceled and restarted with the new
+		// interval. This method is always non-blocking and must not be called
+		// after Stop.
+		Reset(interval time.Duration)
+
+		// Pause suspends the firing of the target function, either on an
+		// interval or via Signal, until Resume is called. This method is
+		// always non-blocking and must not be called after Stop. Stop may
+		// still be called while paused. A Signal sent while paused is
+		// queued and will fire as soon as Resume is called, the same as a
+		// Signal that arrives while a previous invocation is still running.
+		Pause()
+
+		// Resume undoes a prior call to Pause, allowing the target function
+		// to be fired again on an interval or via Signal. The interval wait
+		// is restarted in full, as if the scheduler had just fired; any
+		// part of the interval that elapsed while paused does not carry
+		// over. This method is always non-blocking and must not be called
+		// after Stop.
+		Resume()
+
+		// Wait blocks until the scheduler's Start goroutine has returned,
+		// which requires Stop to have been called first. It is safe to call
+		// Wait from multiple goroutines.
+		Wait()
 	}
 
 	scheduler struct {
@@ -31,6 +59,28 @@ type (
 		withChan func(f func(chan struct{}))
 		quit     chan struct{}
 		signal   chan struct{}
+		reset    chan time.Duration
+		pause    chan struct{}
+		resume   chan struct{}
+
+		backoffEnabled bool
+		backoffMin     time.Duration
+		backoffMax     time.Duration
+		backoffFactor  float64
+		backoffJitter  float64
+
+		// backoffMutex guards currentBackoff, which is written both from
+		// Signal (callable from any goroutine per its documented contract)
+		// and from onFailure/onSuccess (invoked on the scheduler's own
+		// goroutine while an invocation completes).
+		backoffMutex   sync.Mutex
+		currentBackoff time.Duration
+
+		schedule ScheduleSource
+
+		ctxRunner *ctxRunner
+
+		done chan struct{}
 	}
 
 	// ConfigFunc is a function used to initialize a new scheduler.
@@ -39,6 +89,39 @@ type (
 
 // NewScheduler creates a new scheduler that will invoke the target function.
 func NewScheduler(target func(), configs ...ConfigFunc) Scheduler {
+	scheduler := newScheduler()
+	scheduler.target = target
+
+	for _, config := range configs {
+		config(scheduler)
+	}
+
+	return scheduler
+}
+
+// NewRetryScheduler creates a new scheduler that will invoke the target
+// function, which reports success or failure via its error return value.
+// When combined with WithBackoff, the interval between invocations grows
+// geometrically after a failing invocation and resets to the configured
+// minimum after a successful one.
+func NewRetryScheduler(target func() error, configs ...ConfigFunc) Scheduler {
+	scheduler := newScheduler()
+	scheduler.target = func() {
+		if err := target(); err != nil {
+			scheduler.onFailure()
+		} else {
+			scheduler.onSuccess()
+		}
+	}
+
+	for _, config := range configs {
+		config(scheduler)
+	}
+
+	return scheduler
+}
+
+func newScheduler() *scheduler {
 	withChan := func(f func(chan struct{})) {
 		quit := make(chan struct{})
 		defer close(quit)
@@ -46,20 +129,17 @@ func NewScheduler(target func(), configs ...ConfigFunc) Scheduler {
 		f(hammer(quit))
 	}
 
-	scheduler := &scheduler{
-		target:   target,
+	return &scheduler{
 		interval: time.Second,
 		clock:    glock.NewRealClock(),
 		withChan: withChan,
 		quit:     make(chan struct{}),
 		signal:   make(chan struct{}, 1),
+		reset:    make(chan time.Duration, 1),
+		pause:    make(chan struct{}, 1),
+		resume:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
 	}
-
-	for _, config := range configs {
-		config(scheduler)
-	}
-
-	return scheduler
 }
 
 // WithInterval sets the interval at which the scheduler will invoke the
@@ -87,20 +167,51 @@ func withClock(clock glock.Clock) ConfigFunc {
 	return func(s *scheduler) { s.clock = clock }
 }
 
+// WithClock sets the clock used by the scheduler for timing. This is mostly
+// useful for sharing a single clock across the schedulers in a
+// SchedulerGroup, or for driving a scheduler from a test's virtual clock.
+func WithClock(clock glock.Clock) ConfigFunc {
+	return withClock(clock)
+}
+
 func (s *scheduler) Start() {
 	go func() {
+		defer close(s.done)
 		defer close(s.signal)
 
 		s.withChan(func(c chan struct{}) {
 			t := throttle(c, s.signal)
+			timer := s.clock.After(s.nextWait())
+			paused := false
 
 			for {
+				tChan, timerChan := t, timer
+				if paused {
+					tChan, timerChan = nil, nil
+				}
+
 				select {
-				case <-t:
+				case <-tChan:
 					s.target()
 
-				case <-s.clock.After(s.interval):
-					s.Signal()
+				case <-timerChan:
+					s.fire()
+					timer = s.clock.After(s.nextWait())
+
+				case interval := <-s.reset:
+					s.interval = interval
+					timer = s.clock.After(s.nextWait())
+
+				case <-s.pause:
+					paused = true
+
+				case <-s.resume:
+					paused = false
+					// The timer created before (or during) the pause may
+					// already have fired and be sitting on the channel;
+					// discard it and start the wait fresh so that Resume
+					// does not trigger an immediate, stale fire.
+					timer = s.clock.After(s.nextWait())
 
 				case <-s.quit:
 					return
@@ -114,13 +225,115 @@ func (s *scheduler) Stop() {
 	close(s.quit)
 }
 
+func (s *scheduler) Wait() {
+	<-s.done
+}
+
 func (s *scheduler) Signal() {
+	if s.backoffEnabled {
+		s.backoffMutex.Lock()
+		s.currentBackoff = s.backoffMin
+		s.backoffMutex.Unlock()
+
+		s.Reset(s.backoffMin)
+	}
+
+	s.fire()
+}
+
+// fire pushes a fire onto the signal channel without the backoff-reset side
+// effect of Signal. It is used for the scheduler's own interval timer, which
+// must not reset backoff growth on every tick the way an explicit, external
+// Signal does.
+func (s *scheduler) fire() {
 	select {
 	case s.signal <- struct{}{}:
 	default:
 	}
 }
 
+func (s *scheduler) onFailure() {
+	if !s.backoffEnabled {
+		return
+	}
+
+	s.backoffMutex.Lock()
+	next := time.Duration(float64(s.currentBackoff) * s.backoffFactor)
+	if next > s.backoffMax {
+		next = s.backoffMax
+	}
+	s.currentBackoff = next
+	s.backoffMutex.Unlock()
+
+	s.Reset(jitter(next, s.backoffJitter))
+}
+
+func (s *scheduler) onSuccess() {
+	if !s.backoffEnabled {
+		return
+	}
+
+	s.backoffMutex.Lock()
+	s.currentBackoff = s.backoffMin
+	s.backoffMutex.Unlock()
+
+	s.Reset(s.backoffMin)
+}
+
+func (s *scheduler) Reset(interval time.Duration) {
+	// An interval the scheduler goroutine has not picked up yet is replaced,
+	// so that the most recent call to Reset wins.
+	for {
+		select {
+		case s.reset <- interval:
+			return
+		default:
+		}
+
+		select {
+		case <-s.reset:
+		default:
+		}
+	}
+}
+
+func (s *scheduler) Pause() {
+	// Discard a Resume not yet picked up, so it cannot be applied after
+	// this Pause.
+	select {
+	case <-s.resume:
+	default:
+	}
+
+	select {
+	case s.pause <- struct{}{}:
+	default:
+	}
+}
+
+func (s *scheduler) Resume() {
+	// Discard a Pause not yet picked up, so it cannot be applied after
+	// this Resume.
+	select {
+	case <-s.pause:
+	default:
+	}
+
+	select {
+	case s.resume <- struct{}{}:
+	default:
+	}
+}
+
+func (s *scheduler) nextWait() time.Duration {
+	if s.schedule != nil {
+		now := s.clock.Now()
+		return s.schedule.Next(now).Sub(now)
+	}
+
+	return s.interval
+}
+
 func hammer(quit <-chan struct{}) chan struct{} {
 	ch := make(chan struct{})
 
@@ -5,36 +5,31 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+
+	"github.com/sniperkit/recurrent/recurrenttest"
 )
 
-type SchedulerSuite struct{}
+func TestAutomaticPeriod(t *testing.T) {
+	RegisterTestingT(t)
 
-func (s *SchedulerSuite) TestAutomaticPeriod(t *testing.T) {
 	var (
-		attempts  = 0
-		clockChan = make(chan time.Time)
-		clock     = newMockClock(clockChan, nil)
-		sync      = make(chan struct{})
-		done      = make(chan struct{})
+		attempts = 0
+		sync     = make(chan struct{})
+		done     = make(chan struct{})
 	)
 
-	defer close(sync)
-	defer close(clockChan)
-
-	scheduler := newSchedulerWithClock(
-		time.Second,
+	scheduler := NewScheduler(
 		func() {
 			attempts++
 			sync <- struct{}{}
 		},
-		clock,
+		WithInterval(time.Millisecond),
 	)
 
 	go func() {
 		defer close(done)
 
 		for i := 0; i < 25; i++ {
-			clockChan <- time.Now()
 			<-sync
 		}
 	}()
@@ -42,82 +37,62 @@ func (s *SchedulerSuite) TestAutomaticPeriod(t *testing.T) {
 	scheduler.Start()
 	<-done
 	scheduler.Stop()
+
 	Expect(attempts).To(Equal(25))
-	Expect(clock.afterArgs[0]).To(Equal(time.Second))
 }
 
-func (s *SchedulerSuite) TestThrottledSchedule(t *testing.T) {
+func TestThrottledSchedule(t *testing.T) {
+	RegisterTestingT(t)
+
 	var (
-		attempts  = 0
-		tickChan  = make(chan time.Time)
-		ticker    = newMockTicker(tickChan)
-		clockChan = make(chan time.Time)
-		clock     = newMockClock(clockChan, ticker)
-		sync      = make(chan struct{})
-		done      = make(chan struct{})
+		attempts = 0
+		sync     = make(chan struct{})
+		done     = make(chan struct{})
 	)
 
-	defer close(sync)
-	defer close(clockChan)
-
-	scheduler := newThrottledSchedulerWithClock(
-		time.Second,
-		time.Millisecond,
+	scheduler := NewScheduler(
 		func() {
 			attempts++
 			sync <- struct{}{}
 		},
-		clock,
+		WithInterval(time.Millisecond),
+		WithThrottle(time.Millisecond),
 	)
 
 	go func() {
 		defer close(done)
 
 		for i := 0; i < 25; i++ {
-			clockChan <- time.Now()
 			<-sync
 		}
 	}()
 
-	go func() {
-		for {
-			select {
-			case <-done:
-				return
-			case tickChan <- time.Now():
-			}
-		}
-	}()
-
 	scheduler.Start()
 	<-done
 	scheduler.Stop()
+
 	Expect(attempts).To(Equal(25))
-	Expect(clock.tickerArgs).To(HaveLen(1))
-	Expect(clock.tickerArgs[0]).To(Equal(time.Millisecond))
 }
 
-func (s *SchedulerSuite) TestExplicitFire(t *testing.T) {
+func TestExplicitFire(t *testing.T) {
+	RegisterTestingT(t)
+
 	var (
-		attempts  = 0
-		clockChan = make(chan time.Time)
-		clock     = newMockClock(clockChan, nil)
-		sync      = make(chan struct{})
-		done      = make(chan struct{})
+		attempts = 0
+		sync     = make(chan struct{})
+		done     = make(chan struct{})
 	)
 
-	defer close(sync)
-	defer close(clockChan)
-
-	scheduler := newSchedulerWithClock(
-		time.Second,
+	scheduler := NewScheduler(
 		func() {
 			attempts++
 			sync <- struct{}{}
 		},
-		clock,
+		WithInterval(time.Hour),
 	)
 
+	scheduler.Start()
+
 	go func() {
 		defer close(done)
 
@@ -127,101 +102,132 @@ func (s *SchedulerSuite) TestExplicitFire(t *testing.T) {
 		}
 	}()
 
-	scheduler.Start()
 	<-done
 	scheduler.Stop()
+
 	Expect(attempts).To(Equal(25))
 }
 
-func (s *SchedulerSuite) TestThrottledExplicitFire(t *testing.T) {
+func TestThrottledExplicitFire(t *testing.T) {
+	RegisterTestingT(t)
+
 	var (
-		attempts  = 0
-		tickChan  = make(chan time.Time)
-		ticker    = newMockTicker(tickChan)
-		clockChan = make(chan time.Time)
-		clock     = newMockClock(clockChan, ticker)
-		sync      = make(chan struct{})
-		done      = make(chan struct{})
+		attempts = 0
+		clock    = recurrenttest.NewClock()
+		sync     = make(chan struct{})
+		done     = make(chan struct{})
 	)
 
-	defer close(sync)
-	defer close(clockChan)
-
-	scheduler := newThrottledSchedulerWithClock(
-		time.Second,
-		time.Millisecond,
+	scheduler := NewScheduler(
 		func() {
 			attempts++
 			sync <- struct{}{}
 		},
-		clock,
+		WithInterval(time.Hour),
+		WithThrottle(time.Millisecond),
+		WithClock(clock),
 	)
 
+	scheduler.Start()
+
+	// Wait for the scheduler goroutine to register its main timer with the
+	// clock, which happens only after WithThrottle's ticker is set up, so
+	// the Add calls below can't race with either being created.
+	clock.BlockUntil(1)
+
 	go func() {
 		defer close(done)
 
+		// Four signals arrive for every tick of the throttle, but only one
+		// invocation is let through per tick, so 25 ticks yield exactly 25
+		// attempts out of 100 signals.
 		for i := 0; i < 100; i++ {
 			scheduler.Signal()
 
 			if i%4 == 0 {
-				tickChan <- time.Now()
+				clock.Add(time.Millisecond)
 				<-sync
 			}
 		}
 	}()
 
-	scheduler.Start()
 	<-done
 	scheduler.Stop()
+
 	Expect(attempts).To(Equal(25))
 }
 
-//
-//
-//
+func TestResetChangesInterval(t *testing.T) {
+	RegisterTestingT(t)
 
-type mockClock struct {
-	ch         <-chan time.Time
-	ticker     ticker
-	afterArgs  []time.Duration
-	tickerArgs []time.Duration
-}
+	var (
+		clock = recurrenttest.NewClock()
+		calls = make(chan struct{}, 1)
+	)
 
-type mockTicker struct {
-	ch      chan time.Time
-	stopped bool
-}
+	scheduler := NewScheduler(
+		func() {
+			select {
+			case calls <- struct{}{}:
+			default:
+			}
+		},
+		WithInterval(time.Hour),
+		WithClock(clock),
+	)
 
-func newMockClock(ch chan time.Time, ticker ticker) *mockClock {
-	return &mockClock{
-		ch:         ch,
-		ticker:     ticker,
-		afterArgs:  []time.Duration{},
-		tickerArgs: []time.Duration{},
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	clock.BlockUntil(1)
+
+	// Reset to a much shorter interval. glock's MockClock never discards a
+	// superseded After call, so the stale hour-long timer is still
+	// registered alongside the new one; block until both are pending
+	// before advancing, so the Add below can't race with the scheduler
+	// goroutine still processing the reset.
+	scheduler.Reset(10 * time.Millisecond)
+	clock.BlockUntil(2)
+	clock.Add(10 * time.Millisecond)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not fire on the new interval after Reset")
 	}
 }
 
-func newMockTicker(ch chan time.Time) *mockTicker {
-	return &mockTicker{
-		ch:      ch,
-		stopped: false,
-	}
-}
+func TestPauseResumeDiscardsStaleTimer(t *testing.T) {
+	RegisterTestingT(t)
 
-func (m *mockClock) After(duration time.Duration) <-chan time.Time {
-	m.afterArgs = append(m.afterArgs, duration)
-	return m.ch
-}
+	fired := make(chan struct{}, 1)
 
-func (m *mockClock) NewTicker(duration time.Duration) ticker {
-	m.tickerArgs = append(m.tickerArgs, duration)
-	return m.ticker
-}
+	scheduler := NewScheduler(
+		func() {
+			select {
+			case fired <- struct{}{}:
+			default:
+			}
+		},
+		WithInterval(20*time.Millisecond),
+	)
 
-func (m *mockTicker) Chan() <-chan time.Time {
-	return m.ch
-}
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	scheduler.Pause()
+	time.Sleep(100 * time.Millisecond) // well past the interval, while paused
+	scheduler.Resume()
 
-func (m *mockTicker) Stop() {
-	m.stopped = true
+	select {
+	case <-fired:
+		t.Fatal("target fired immediately on resume from a stale timer")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	select {
+	case <-fired:
+	case <-time.After(60 * time.Millisecond):
+		t.Fatal("target never fired after resuming")
+	}
 }